@@ -0,0 +1,71 @@
+package urn
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+)
+
+// IsZero reports whether urn is nil or the zero value, i.e. it wasn't
+// produced by New, Parse or one of the Unmarshal/Scan methods.
+func (urn *URN) IsZero() bool {
+	return urn == nil || (len(urn.nid) == 0 && len(urn.nss) == 0)
+}
+
+// MarshalText - implements encoding.TextMarshaler, so URN works
+// transparently with XML/YAML/TOML and other libraries built on top of
+// the encoding package. Returns nil for a nil or zero-value URN.
+func (urn *URN) MarshalText() ([]byte, error) {
+	if urn.IsZero() {
+		return nil, nil
+	}
+
+	return urn.AppendFormat(nil), nil
+}
+
+// UnmarshalText - implements encoding.TextUnmarshaler. An empty text
+// resets urn to its zero value.
+func (urn *URN) UnmarshalText(text []byte) error {
+	if len(bytes.TrimSpace(text)) == 0 {
+		*urn = URN{}
+		return nil
+	}
+
+	parsed, err := parseRawURN(bytes.TrimSpace(text), parseConfig{})
+	if err != nil {
+		return err
+	}
+
+	*urn = *parsed
+
+	return nil
+}
+
+// Scan - implements sql.Scanner, so a *URN column can be read directly
+// out of database/sql query results.
+func (urn *URN) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*urn = URN{}
+		return nil
+	case string:
+		return urn.UnmarshalText([]byte(v))
+	case []byte:
+		return urn.UnmarshalText(v)
+	default:
+		return fmt.Errorf(
+			"can't scan URN, reason: unsupported source type %T",
+			src,
+		)
+	}
+}
+
+// Value - implements driver.Valuer, so a *URN can be passed directly as
+// a database/sql query argument.
+func (urn *URN) Value() (driver.Value, error) {
+	if urn.IsZero() {
+		return nil, nil
+	}
+
+	return urn.String(), nil
+}
@@ -0,0 +1,123 @@
+package urn
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestURN_IsZero(t *testing.T) {
+	var zero URN
+	assert.True(t, zero.IsZero())
+
+	var nilURN *URN
+	assert.True(t, nilURN.IsZero())
+
+	u, err := New("newtonworld228", "nss")
+	assert.NoError(t, err)
+	assert.False(t, u.IsZero())
+}
+
+func TestURN_MarshalJSON_Null(t *testing.T) {
+	var zero URN
+
+	b, err := zero.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+
+	var nilURN *URN
+
+	b, err = nilURN.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, "null", string(b))
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type wrapper struct {
+		URN *URN `json:"urn"`
+	}
+
+	original := wrapper{URN: MustParse("urn:newtonworld228:lol%AC_45:rRR")}
+
+	encoded, err := json.Marshal(original)
+	assert.NoError(t, err)
+
+	var decoded wrapper
+	assert.NoError(t, json.Unmarshal(encoded, &decoded))
+	assert.True(t, original.URN.Equal(decoded.URN))
+
+	nullWrapper := wrapper{}
+	encodedNull, err := json.Marshal(nullWrapper)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"urn":null}`, string(encodedNull))
+}
+
+type xmlWrapper struct {
+	XMLName xml.Name `xml:"wrapper"`
+	URN     *URN     `xml:"urn"`
+}
+
+func TestXMLRoundTrip(t *testing.T) {
+	original := xmlWrapper{URN: MustParse("urn:newtonworld228:lol%AC_45:rRR")}
+
+	encoded, err := xml.Marshal(original)
+	assert.NoError(t, err)
+
+	var decoded xmlWrapper
+	assert.NoError(t, xml.Unmarshal(encoded, &decoded))
+	assert.True(t, original.URN.Equal(decoded.URN))
+}
+
+func TestURN_ScanAndValue(t *testing.T) {
+	var scanned URN
+
+	assert.NoError(t, scanned.Scan("urn:newtonworld228:lol%AC_45:rRR"))
+	assert.Equal(t, "urn:newtonworld228:lol%AC_45:rRR", scanned.String())
+
+	assert.NoError(t, scanned.Scan([]byte("urn:newtonworld228:other-nss")))
+	assert.Equal(t, "urn:newtonworld228:other-nss", scanned.String())
+
+	assert.NoError(t, scanned.Scan(nil))
+	assert.True(t, scanned.IsZero())
+
+	assert.Error(t, scanned.Scan(42))
+
+	u := MustParse("urn:newtonworld228:lol%AC_45:rRR")
+	value, err := u.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:newtonworld228:lol%AC_45:rRR", value)
+
+	var zero URN
+	zeroValue, err := zero.Value()
+	assert.NoError(t, err)
+	assert.Nil(t, zeroValue)
+}
+
+func TestURN_SQLRoundTrip(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+
+	original := MustParse("urn:newtonworld228:lol%AC_45:rRR")
+
+	mock.ExpectQuery("^SELECT urn FROM widgets WHERE id = \\?$").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"urn"}).AddRow(original.String()))
+
+	mock.ExpectExec("^INSERT INTO widgets \\(id, urn\\) VALUES \\(\\?, \\?\\)$").
+		WithArgs(2, original).
+		WillReturnResult(sqlmock.NewResult(2, 1))
+
+	var scanned URN
+	row := db.QueryRow("SELECT urn FROM widgets WHERE id = ?", 1)
+	assert.NoError(t, row.Scan(&scanned))
+	assert.True(t, original.Equal(&scanned))
+
+	_, err = db.Exec("INSERT INTO widgets (id, urn) VALUES (?, ?)", 2, original)
+	assert.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
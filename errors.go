@@ -0,0 +1,58 @@
+package urn
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel error kinds. Use errors.Is(err, urn.ErrBadNIDChar) (etc.) to
+// tell parse failures apart without parsing error strings; use
+// errors.As(err, &parseErr) to get at the offending offset and input.
+var (
+	ErrInvalidFormat    = errors.New("urn: invalid format")
+	ErrBadPrefix        = errors.New("urn: bad prefix")
+	ErrReservedNID      = errors.New("urn: reserved NID")
+	ErrExperimentalNID  = errors.New("urn: experimental NID")
+	ErrBadNIDChar       = errors.New("urn: bad NID character")
+	ErrBadNSSEscape     = errors.New("urn: bad NSS escape")
+	ErrNamespaceInvalid = errors.New("urn: namespace validation failed")
+)
+
+// ParseError is returned by New, Parse and ParseReader (and wrapped by
+// RegisterNamespace) for any input that fails validation. It wraps one
+// of the sentinel Err* kinds above, and carries enough context
+// (Input, Offset) to point straight at the offending byte.
+type ParseError struct {
+	// Kind is one of the sentinel Err* errors declared in this file.
+	Kind error
+
+	// Input is the original, unmodified input that failed to parse.
+	Input string
+
+	// Offset is the byte offset of the first invalid byte within
+	// Input, or -1 when the failure isn't tied to a single byte.
+	Offset int
+
+	// Detail is a human-readable description of what went wrong.
+	Detail string
+}
+
+func (e *ParseError) Error() string {
+	if e.Offset < 0 {
+		return fmt.Sprintf("%s: %s", e.Kind, e.Detail)
+	}
+
+	return fmt.Sprintf(
+		"%s: %s (at byte offset %d in %q)",
+		e.Kind,
+		e.Detail,
+		e.Offset,
+		e.Input,
+	)
+}
+
+// Unwrap lets errors.Is(err, ErrBadPrefix) (etc.) see through ParseError
+// to the sentinel kind it wraps.
+func (e *ParseError) Unwrap() error {
+	return e.Kind
+}
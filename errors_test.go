@@ -0,0 +1,51 @@
+package urn
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseError_Is(t *testing.T) {
+	_, err := Parse("not-a-urn")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrBadPrefix))
+	assert.False(t, errors.Is(err, ErrBadNIDChar))
+}
+
+func TestParseError_As(t *testing.T) {
+	_, err := Parse("urn:$sdf:nss")
+
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, ErrBadNIDChar, parseErr.Kind)
+	assert.Equal(t, 4, parseErr.Offset)
+}
+
+func TestParseError_New_Is(t *testing.T) {
+	_, err := New("urn-nid", "nss")
+	assert.True(t, errors.Is(err, ErrReservedNID))
+
+	_, err = New("x-nid", "nss")
+	assert.True(t, errors.Is(err, ErrExperimentalNID))
+}
+
+func TestParseError_Parse_Is(t *testing.T) {
+	_, err := Parse("urn:urn-nid:nss")
+	assert.True(t, errors.Is(err, ErrReservedNID))
+
+	_, err = Parse("urn:x-nid:nss")
+	assert.True(t, errors.Is(err, ErrExperimentalNID))
+
+	_, err = Parse("urn:xy-nid:nss")
+	assert.True(t, errors.Is(err, ErrExperimentalNID))
+}
+
+func TestParseError_Parse_AgreesWithNewOnMinNIDLength(t *testing.T) {
+	_, newErr := New("ab", "validnss1")
+	assert.True(t, errors.Is(newErr, ErrBadNIDChar))
+
+	_, parseErr := Parse("urn:ab:validnss1")
+	assert.True(t, errors.Is(parseErr, ErrBadNIDChar))
+}
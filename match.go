@@ -0,0 +1,97 @@
+package urn
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Matcher matches URNs against a slash-separated pattern, one regexp
+// per hierarchy level, the same way `go test -run` matches subtests by
+// path element: the first level is matched against the NID, and each
+// subsequent level is matched against the corresponding ':'-separated
+// segment of the NSS (so "lex/us/federal" matches urn:lex:us:federal:2024,
+// with "lex" matched against the NID and "us", "federal" against the
+// first two NSS segments).
+// Each level's regexp is matched unanchored, same as -run. A level
+// written as a bare "*" matches anything without being compiled as a
+// regexp, so patterns like "*/.*uuid.*" work. A pattern with more
+// levels than the URN has never matches; one with fewer leaves the
+// remaining levels unconstrained.
+type Matcher struct {
+	levels []*regexp.Regexp // nil entry == "*", matches anything
+}
+
+// NewMatcher precompiles pattern into a Matcher, so repeated calls to
+// Match against the same pattern don't pay regexp.Compile's cost more
+// than once.
+func NewMatcher(pattern string) (*Matcher, error) {
+	parts := strings.Split(pattern, "/")
+	levels := make([]*regexp.Regexp, len(parts))
+
+	for i, part := range parts {
+		if part == "*" {
+			continue
+		}
+
+		re, err := regexp.Compile(part)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"can't compile match pattern %q, reason: %s",
+				pattern,
+				err.Error(),
+			)
+		}
+
+		levels[i] = re
+	}
+
+	return &Matcher{levels: levels}, nil
+}
+
+// Match reports whether u satisfies every level of m.
+func (m *Matcher) Match(u *URN) bool {
+	path := append([]string{u.NID()}, strings.Split(u.NSS(), ":")...)
+
+	if len(m.levels) > len(path) {
+		return false
+	}
+
+	for i, re := range m.levels {
+		if re != nil && !re.MatchString(path[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+var (
+	matcherCacheMu sync.RWMutex
+	matcherCache   = map[string]*Matcher{}
+)
+
+// Match reports whether u matches pattern, compiling pattern into a
+// Matcher on first use and reusing it on subsequent calls (safe for
+// concurrent use). See Matcher for the pattern grammar.
+func Match(pattern string, u *URN) (bool, error) {
+	matcherCacheMu.RLock()
+	m, ok := matcherCache[pattern]
+	matcherCacheMu.RUnlock()
+
+	if !ok {
+		var err error
+
+		m, err = NewMatcher(pattern)
+		if err != nil {
+			return false, err
+		}
+
+		matcherCacheMu.Lock()
+		matcherCache[pattern] = m
+		matcherCacheMu.Unlock()
+	}
+
+	return m.Match(u), nil
+}
@@ -0,0 +1,93 @@
+package urn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		name    string
+		pattern string
+		URN     string
+
+		expectMatch bool
+		expectError bool
+	}{
+		{
+			name:        "exact NID and NSS prefix",
+			pattern:     "book/097.*",
+			URN:         "urn:book:0971234567890",
+			expectMatch: true,
+		},
+		{
+			name:        "exact NID, non-matching NSS",
+			pattern:     "book/097.*",
+			URN:         "urn:book:0451450523",
+			expectMatch: false,
+		},
+		{
+			name:        "wildcard NID, content match on NSS",
+			pattern:     "*/.*uuid.*",
+			URN:         "urn:lex:this-is-a-uuid-lookalike",
+			expectMatch: true,
+		},
+		{
+			name:        "hierarchical NSS segments",
+			pattern:     "lex/us/federal",
+			URN:         "urn:lex:us:federal:2024",
+			expectMatch: true,
+		},
+		{
+			name:        "hierarchical NSS segment mismatch",
+			pattern:     "lex/us/state",
+			URN:         "urn:lex:us:federal:2024",
+			expectMatch: false,
+		},
+		{
+			name:        "pattern with more levels than URN never matches",
+			pattern:     "lex/us/federal/2024/extra",
+			URN:         "urn:lex:us:federal:2024",
+			expectMatch: false,
+		},
+		{
+			name:        "pattern with fewer levels leaves the rest unconstrained",
+			pattern:     "lex",
+			URN:         "urn:lex:us:federal:2024",
+			expectMatch: true,
+		},
+		{
+			name:        "bad pattern fails to compile",
+			pattern:     "book/(",
+			URN:         "urn:book:0971234567890",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				u := MustParse(testCase.URN)
+
+				matched, err := Match(testCase.pattern, u)
+				if testCase.expectError {
+					assert.Error(t, err)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectMatch, matched)
+			},
+		)
+	}
+}
+
+func TestMatcher_Reused(t *testing.T) {
+	m, err := NewMatcher("book/097.*")
+	assert.NoError(t, err)
+
+	assert.True(t, m.Match(MustParse("urn:book:0971234567890")))
+	assert.False(t, m.Match(MustParse("urn:book:0451450523")))
+}
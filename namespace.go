@@ -0,0 +1,159 @@
+package urn
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+)
+
+// NamespaceSpec describes everything `urn` knows about a registered NID:
+// how to validate (and optionally canonicalize) its NSS, and how to
+// resolve a URN in that namespace to whatever a backend considers its
+// resolved value.
+type NamespaceSpec struct {
+	// Validate checks that nss is a syntactically valid NSS for this
+	// namespace. It receives the raw, still percent-escaped NSS bytes
+	// exactly as produced by the parser. Required.
+	Validate func(nss []byte) error
+
+	// Canonicalize, if set, rewrites nss into its canonical form (e.g.
+	// lower-casing hex digits). It's only called once Validate has
+	// succeeded, and its result is what gets stored on the URN.
+	Canonicalize func(nss []byte) []byte
+
+	// Resolver, if set, lets Resolve dereference URNs in this namespace
+	// through a user-supplied backend (DNS, HTTP, database, ...).
+	Resolver Resolver
+}
+
+// Resolver dereferences the NSS of a URN belonging to a registered
+// namespace into whatever the backend considers the resolved value.
+type Resolver interface {
+	Resolve(ctx context.Context, u *URN) (string, error)
+}
+
+var (
+	namespacesMu sync.RWMutex
+	namespaces   = map[string]NamespaceSpec{}
+)
+
+// RegisterNamespace associates nid with spec so that New and Parse will
+// reject URNs whose NSS doesn't satisfy spec.Validate, and so that
+// Resolve can dereference URNs in this namespace. nid is matched
+// case-insensitively, same as the rest of the package.
+//
+// Registering the same nid twice overwrites the previous spec.
+func RegisterNamespace(nid string, spec NamespaceSpec) error {
+	if spec.Validate == nil {
+		return fmt.Errorf(
+			"can't register namespace %s, reason: NamespaceSpec.Validate is required",
+			nid,
+		)
+	}
+
+	bNID := bytes.ToLower(bytes.TrimSpace([]byte(nid)))
+
+	if err := validateNID(bNID); err != nil {
+		return fmt.Errorf(
+			"can't register namespace, reason: %w",
+			err,
+		)
+	}
+
+	namespacesMu.Lock()
+	defer namespacesMu.Unlock()
+
+	namespaces[string(bNID)] = spec
+
+	return nil
+}
+
+// LookupNamespace returns the spec registered for nid, if any.
+func LookupNamespace(nid string) (NamespaceSpec, bool) {
+	return lookupNamespace([]byte(nid))
+}
+
+// lookupNamespace is LookupNamespace's byte-slice-accepting counterpart,
+// used internally so the hot New/Parse path doesn't have to round-trip
+// the NID through a string first. For NIDs within maxNIDLength (the
+// common case, since validateNID rejects anything longer), it
+// lower-cases nid into a stack buffer instead of calling bytes.ToLower,
+// so looking up an unregistered NID doesn't allocate.
+func lookupNamespace(nid []byte) (NamespaceSpec, bool) {
+	namespacesMu.RLock()
+	defer namespacesMu.RUnlock()
+
+	if len(namespaces) == 0 {
+		return NamespaceSpec{}, false
+	}
+
+	if len(nid) > maxNIDLength {
+		spec, ok := namespaces[string(bytes.ToLower(nid))]
+		return spec, ok
+	}
+
+	var buf [maxNIDLength]byte
+	for i, b := range nid {
+		buf[i] = toLowerASCII(b)
+	}
+
+	spec, ok := namespaces[string(buf[:len(nid)])]
+
+	return spec, ok
+}
+
+func toLowerASCII(b byte) byte {
+	if b >= 'A' && b <= 'Z' {
+		return b + ('a' - 'A')
+	}
+
+	return b
+}
+
+// Resolve dereferences u through the Resolver registered for its NID.
+// It returns an error if no namespace is registered for u's NID, or if
+// the registered namespace has no Resolver.
+func Resolve(ctx context.Context, u *URN) (string, error) {
+	spec, ok := LookupNamespace(string(u.nid))
+	if !ok {
+		return "", fmt.Errorf(
+			"can't resolve URN, reason: no namespace registered for NID %s",
+			u.nid,
+		)
+	}
+
+	if spec.Resolver == nil {
+		return "", fmt.Errorf(
+			"can't resolve URN, reason: namespace %s has no resolver",
+			u.nid,
+		)
+	}
+
+	return spec.Resolver.Resolve(ctx, u)
+}
+
+// applyNamespace validates nss against the namespace registered for nid,
+// if any, and returns its canonicalized form. It's a no-op when nid has
+// no registered namespace.
+func applyNamespace(nid, nss []byte) ([]byte, error) {
+	spec, ok := lookupNamespace(nid)
+	if !ok {
+		return nss, nil
+	}
+
+	if err := spec.Validate(nss); err != nil {
+		return nil, &ParseError{
+			Kind:   ErrNamespaceInvalid,
+			Input:  string(nss),
+			Offset: -1,
+			Detail: fmt.Sprintf("NSS %s is not valid for namespace %s: %s", nss, nid, err.Error()),
+		}
+	}
+
+	if spec.Canonicalize != nil {
+		nss = spec.Canonicalize(nss)
+	}
+
+	return nss, nil
+}
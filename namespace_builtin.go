@@ -0,0 +1,130 @@
+package urn
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+// https://tools.ietf.org/html/rfc4122
+var uuidRegexp = regexp.MustCompile(
+	`^(?i)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`,
+)
+
+// https://www.itu.int/ITU-T/asn1/uuid.html
+var oidRegexp = regexp.MustCompile(
+	`^[0-2](\.(0|[1-9][0-9]*))+$`,
+)
+
+func init() {
+	_ = RegisterNamespace("uuid", NamespaceSpec{
+		Validate:     validateUUIDNSS,
+		Canonicalize: canonicalizeUUIDNSS,
+	})
+
+	_ = RegisterNamespace("isbn", NamespaceSpec{
+		Validate: validateISBNNSS,
+	})
+
+	_ = RegisterNamespace("oid", NamespaceSpec{
+		Validate: validateOIDNSS,
+	})
+}
+
+// validateUUIDNSS - https://tools.ietf.org/html/rfc4122
+func validateUUIDNSS(nss []byte) error {
+	if !uuidRegexp.Match(nss) {
+		return fmt.Errorf(
+			"NSS %s is not a valid UUID",
+			nss,
+		)
+	}
+
+	return nil
+}
+
+func canonicalizeUUIDNSS(nss []byte) []byte {
+	return bytes.ToLower(nss)
+}
+
+// validateOIDNSS - dot-delimited arc notation, e.g. 1.2.840.113549
+func validateOIDNSS(nss []byte) error {
+	if !oidRegexp.Match(nss) {
+		return fmt.Errorf(
+			"NSS %s is not a valid OID",
+			nss,
+		)
+	}
+
+	return nil
+}
+
+// validateISBNNSS accepts both hyphenated and plain ISBN-10/ISBN-13
+// strings and checks their checksum digit.
+func validateISBNNSS(nss []byte) error {
+	clean := bytes.ReplaceAll(nss, []byte("-"), nil)
+
+	switch len(clean) {
+	case 10:
+		if !isValidISBN10(clean) {
+			return fmt.Errorf(
+				"NSS %s is not a valid ISBN-10",
+				nss,
+			)
+		}
+	case 13:
+		if !isValidISBN13(clean) {
+			return fmt.Errorf(
+				"NSS %s is not a valid ISBN-13",
+				nss,
+			)
+		}
+	default:
+		return fmt.Errorf(
+			"NSS %s must contain 10 or 13 digits to be a valid ISBN",
+			nss,
+		)
+	}
+
+	return nil
+}
+
+func isValidISBN10(digits []byte) bool {
+	sum := 0
+
+	for i := 0; i < 10; i++ {
+		var d int
+
+		switch {
+		case digits[i] >= '0' && digits[i] <= '9':
+			d = int(digits[i] - '0')
+		case (digits[i] == 'X' || digits[i] == 'x') && i == 9:
+			d = 10
+		default:
+			return false
+		}
+
+		sum += (10 - i) * d
+	}
+
+	return sum%11 == 0
+}
+
+func isValidISBN13(digits []byte) bool {
+	sum := 0
+
+	for i := 0; i < 13; i++ {
+		if digits[i] < '0' || digits[i] > '9' {
+			return false
+		}
+
+		d := int(digits[i] - '0')
+		if i%2 == 0 {
+			sum += d
+		} else {
+			sum += d * 3
+		}
+	}
+
+	return sum%10 == 0
+}
@@ -0,0 +1,130 @@
+package urn
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterNamespace(t *testing.T) {
+	err := RegisterNamespace("t-custom", NamespaceSpec{
+		Validate: func(nss []byte) error { return nil },
+	})
+	assert.NoError(t, err)
+
+	_, ok := LookupNamespace("T-Custom")
+	assert.True(t, ok)
+
+	err = RegisterNamespace("t-broken", NamespaceSpec{})
+	assert.Error(t, err)
+}
+
+func TestParseURN_NamespaceValidationErrorIsTyped(t *testing.T) {
+	_, err := Parse("urn:uuid:not-a-uuid")
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNamespaceInvalid))
+
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, ErrNamespaceInvalid, parseErr.Kind)
+
+	_, err = New("isbn", "not-a-valid-isbn")
+	assert.True(t, errors.Is(err, ErrNamespaceInvalid))
+}
+
+func TestParseURN_BuiltinNamespaces(t *testing.T) {
+	testCases := []struct {
+		name string
+		URN  string
+
+		expectError bool
+	}{
+		{
+			name: "valid uuid",
+			URN:  "urn:uuid:F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6",
+		},
+		{
+			name:        "invalid uuid",
+			URN:         "urn:uuid:not-a-uuid",
+			expectError: true,
+		},
+		{
+			name: "valid isbn-10",
+			URN:  "urn:isbn:0451450523",
+		},
+		{
+			name: "valid isbn-13",
+			URN:  "urn:isbn:9780451450524",
+		},
+		{
+			name:        "invalid isbn checksum",
+			URN:         "urn:isbn:0451450524",
+			expectError: true,
+		},
+		{
+			name: "valid oid",
+			URN:  "urn:oid:1.2.840.113549",
+		},
+		{
+			name:        "invalid oid",
+			URN:         "urn:oid:not-an-oid",
+			expectError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				u, err := Parse(testCase.URN)
+
+				if testCase.expectError {
+					assert.Error(t, err)
+					assert.Nil(t, u)
+					return
+				}
+
+				assert.NoError(t, err)
+				assert.NotNil(t, u)
+			},
+		)
+	}
+}
+
+func TestParseURN_UUIDIsCanonicalized(t *testing.T) {
+	u, err := Parse("urn:uuid:F81D4FAE-7DEC-11D0-A765-00A0C91E6BF6")
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:uuid:f81d4fae-7dec-11d0-a765-00a0c91e6bf6", u.String())
+}
+
+type stubResolver struct {
+	result string
+	err    error
+}
+
+func (s stubResolver) Resolve(ctx context.Context, u *URN) (string, error) {
+	return s.result, s.err
+}
+
+func TestResolve(t *testing.T) {
+	err := RegisterNamespace("t-resolvable", NamespaceSpec{
+		Validate: func(nss []byte) error { return nil },
+		Resolver: stubResolver{result: "resolved-value"},
+	})
+	assert.NoError(t, err)
+
+	u, err := New("t-resolvable", "anything")
+	assert.NoError(t, err)
+
+	result, err := Resolve(context.Background(), u)
+	assert.NoError(t, err)
+	assert.Equal(t, "resolved-value", result)
+
+	noResolverURN, err := New("newtonworld228", "anything")
+	assert.NoError(t, err)
+
+	_, err = Resolve(context.Background(), noResolverURN)
+	assert.Error(t, err)
+}
@@ -0,0 +1,39 @@
+package urn
+
+import (
+	"sync"
+	"time"
+)
+
+// ParseObserver is notified after every New, Parse, MustParse and
+// ParseReader call with that call's outcome (nil on success) and how
+// long it took. Instrumentation packages (see urn/urnmetrics) register
+// one via SetParseObserver; this package never calls one on its own.
+type ParseObserver func(err error, duration time.Duration)
+
+var (
+	parseObserverMu sync.RWMutex
+	parseObserver   ParseObserver
+)
+
+// SetParseObserver registers fn to be notified after every construct/parse
+// call made through this package, replacing any previously registered
+// observer. Passing nil disables observation. fn is purely for
+// instrumentation - it cannot affect the outcome of the call it observes,
+// and must not call back into this package.
+func SetParseObserver(fn ParseObserver) {
+	parseObserverMu.Lock()
+	defer parseObserverMu.Unlock()
+
+	parseObserver = fn
+}
+
+func observeParse(err error, duration time.Duration) {
+	parseObserverMu.RLock()
+	fn := parseObserver
+	parseObserverMu.RUnlock()
+
+	if fn != nil {
+		fn(err, duration)
+	}
+}
@@ -0,0 +1,35 @@
+package urn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetParseObserver(t *testing.T) {
+	defer SetParseObserver(nil)
+
+	var gotErr error
+	var calls int
+
+	SetParseObserver(func(err error, duration time.Duration) {
+		calls++
+		gotErr = err
+		assert.True(t, duration >= 0)
+	})
+
+	_, err := Parse("urn:newtonworld228:nss")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.NoError(t, gotErr)
+
+	_, err = Parse("not-a-urn")
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, err, gotErr)
+
+	_, err = New("newtonworld228", "nss")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,247 @@
+package urn
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// https://tools.ietf.org/html/rfc8141#section-2.3
+var (
+	rComponentDelimiter = []byte("?+")
+	qComponentDelimiter = []byte("?=")
+	fComponentDelimiter = []byte("#")
+)
+
+// https://tools.ietf.org/html/rfc8141#section-2.3
+// pchar, plus "/" - the "?" that introduces r/q-components is matched as
+// a delimiter before this regexp ever sees the component's contents.
+var componentRegexp = regexp.MustCompile(
+	`^(?:%[0-9A-Fa-f]{2}|[a-zA-Z0-9\-._~!$&'()*+,;=:@/])*$`,
+)
+
+// ParseOption customizes the behaviour of Parse/MustParse.
+type ParseOption func(*parseConfig)
+
+type parseConfig struct {
+	strict2141 bool
+}
+
+func buildParseConfig(opts []ParseOption) parseConfig {
+	var cfg parseConfig
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+// Strict2141 makes Parse/MustParse reject URNs that use any RFC 8141
+// component (r-component, q-component or fragment), so that only plain
+// RFC 2141 "urn:<nid>:<nss>" strings are accepted.
+func Strict2141() ParseOption {
+	return func(cfg *parseConfig) {
+		cfg.strict2141 = true
+	}
+}
+
+// components holds the raw RFC 8141 components found after the NSS.
+type components struct {
+	nss []byte
+
+	rComponent []byte
+	qComponent []byte
+	fComponent []byte
+
+	hasRComponent bool
+	hasQComponent bool
+	hasFComponent bool
+}
+
+// splitComponents splits the NSS tail of a raw URN (everything after the
+// second ':') into its NSS, r-component, q-component and fragment, per
+// the grammar:
+//
+//	nss [ "?+" rComponent ] [ "?=" qComponent ] [ "#" fComponent ]
+func splitComponents(tail []byte) ([]byte, components) {
+	var c components
+
+	body := tail
+
+	if idx := bytes.IndexByte(body, fComponentDelimiter[0]); idx >= 0 {
+		c.hasFComponent = true
+		c.fComponent = body[idx+1:]
+		body = body[:idx]
+	}
+
+	if idx := bytes.Index(body, rComponentDelimiter); idx >= 0 {
+		c.hasRComponent = true
+		rest := body[idx+len(rComponentDelimiter):]
+		body = body[:idx]
+
+		if qIdx := bytes.Index(rest, qComponentDelimiter); qIdx >= 0 {
+			c.hasQComponent = true
+			c.rComponent = rest[:qIdx]
+			c.qComponent = rest[qIdx+len(qComponentDelimiter):]
+		} else {
+			c.rComponent = rest
+		}
+	} else if idx := bytes.Index(body, qComponentDelimiter); idx >= 0 {
+		c.hasQComponent = true
+		c.qComponent = body[idx+len(qComponentDelimiter):]
+		body = body[:idx]
+	}
+
+	c.nss = body
+
+	return body, c
+}
+
+// validateComponents checks the character class of every component
+// present in c (no regexp - see scanEscapedClass), reporting the
+// offending byte's offset within rawURN. tail is the unmutated
+// NSS-and-components tail returned by scanURN; it's only used to
+// translate a local offset into one relative to rawURN.
+func validateComponents(rawURN, tail []byte, c components) error {
+	tailStart := len(rawURN) - len(tail)
+
+	if c.hasRComponent {
+		offset := scanEscapedClass(c.rComponent, isComponentByte)
+		if len(c.rComponent) == 0 {
+			offset = 0
+		}
+
+		if offset >= 0 {
+			return &ParseError{
+				Kind:   ErrBadNSSEscape,
+				Input:  string(rawURN),
+				Offset: tailStart + bytes.Index(tail, rComponentDelimiter) + len(rComponentDelimiter) + offset,
+				Detail: fmt.Sprintf("r-component %s doesn't satisfy the regexp rule: %s", c.rComponent, componentRegexp),
+			}
+		}
+	}
+
+	if c.hasQComponent {
+		offset := scanEscapedClass(c.qComponent, isComponentByte)
+		if len(c.qComponent) == 0 {
+			offset = 0
+		}
+
+		if offset >= 0 {
+			return &ParseError{
+				Kind:   ErrBadNSSEscape,
+				Input:  string(rawURN),
+				Offset: tailStart + bytes.Index(tail, qComponentDelimiter) + len(qComponentDelimiter) + offset,
+				Detail: fmt.Sprintf("q-component %s doesn't satisfy the regexp rule: %s", c.qComponent, componentRegexp),
+			}
+		}
+	}
+
+	if c.hasFComponent {
+		if offset := scanEscapedClass(c.fComponent, isComponentByte); offset >= 0 {
+			return &ParseError{
+				Kind:   ErrBadNSSEscape,
+				Input:  string(rawURN),
+				Offset: tailStart + bytes.IndexByte(tail, fComponentDelimiter[0]) + len(fComponentDelimiter) + offset,
+				Detail: fmt.Sprintf("fragment %s doesn't satisfy the regexp rule: %s", c.fComponent, componentRegexp),
+			}
+		}
+	}
+
+	return nil
+}
+
+// NID returns the Namespace Identifier.
+func (urn *URN) NID() string {
+	return string(urn.nid)
+}
+
+// NSS returns the Namespace Specific String.
+func (urn *URN) NSS() string {
+	return string(urn.nss)
+}
+
+// RComponent returns the raw r-component (without the leading "?+"), or
+// "" if the URN has none.
+func (urn *URN) RComponent() string {
+	return string(urn.rComponent)
+}
+
+// QComponent parses the q-component (without the leading "?=") as
+// URL-style key/value pairs, the same way url.URL.Query does.
+func (urn *URN) QComponent() url.Values {
+	values, _ := url.ParseQuery(string(urn.qComponent))
+
+	return values
+}
+
+// Fragment returns the f-component (without the leading "#"), or "" if
+// the URN has none.
+func (urn *URN) Fragment() string {
+	return string(urn.fComponent)
+}
+
+// Equal reports whether urn and other identify the same resource per
+// RFC 8141 §3 URN equivalence: NIDs are compared case-insensitively, the
+// NSS is compared using the namespace's own Canonicalize rule when one
+// is registered (see RegisterNamespace), and r/q/f components are
+// ignored.
+func (urn *URN) Equal(other *URN) bool {
+	if urn == nil || other == nil {
+		return urn == other
+	}
+
+	if !bytes.EqualFold(urn.nid, other.nid) {
+		return false
+	}
+
+	aNSS := uppercasePercentEscapes(urn.nss)
+	bNSS := uppercasePercentEscapes(other.nss)
+
+	if spec, ok := LookupNamespace(string(urn.nid)); ok && spec.Canonicalize != nil {
+		aNSS = spec.Canonicalize(aNSS)
+		bNSS = spec.Canonicalize(bNSS)
+	}
+
+	return bytes.Equal(aNSS, bNSS)
+}
+
+// LexicalEquivalent reports whether urn and other are lexically
+// equivalent per RFC 8141 §3.1: a generic, namespace-agnostic
+// comparison that case-folds the NID, canonicalizes "%HH" escapes in
+// the NSS to uppercase, and ignores r/q/f components. Unlike Equal, it
+// never consults the namespace registry.
+func (urn *URN) LexicalEquivalent(other *URN) bool {
+	if urn == nil || other == nil {
+		return urn == other
+	}
+
+	return bytes.EqualFold(urn.nid, other.nid) &&
+		bytes.Equal(uppercasePercentEscapes(urn.nss), uppercasePercentEscapes(other.nss))
+}
+
+// uppercasePercentEscapes returns a copy of b with every "%HH" escape's
+// hex digits upper-cased, as required by RFC 8141 §3.1 normalization.
+func uppercasePercentEscapes(b []byte) []byte {
+	out := make([]byte, len(b))
+	copy(out, b)
+
+	for i := 0; i+2 < len(out); i++ {
+		if out[i] == '%' {
+			out[i+1] = upperHexDigit(out[i+1])
+			out[i+2] = upperHexDigit(out[i+2])
+		}
+	}
+
+	return out
+}
+
+func upperHexDigit(c byte) byte {
+	if c >= 'a' && c <= 'f' {
+		return c - 'a' + 'A'
+	}
+
+	return c
+}
@@ -0,0 +1,46 @@
+package urn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURN_RFC8141Components(t *testing.T) {
+	u, err := Parse("urn:newtonworld228:lol%AC_45:rRR?+res?=a=1&b=2#frag")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "newtonworld228", u.NID())
+	assert.Equal(t, "lol%AC_45:rRR", u.NSS())
+	assert.Equal(t, "res", u.RComponent())
+	assert.Equal(t, []string{"1"}, u.QComponent()["a"])
+	assert.Equal(t, []string{"2"}, u.QComponent()["b"])
+	assert.Equal(t, "frag", u.Fragment())
+	assert.Equal(t, "urn:newtonworld228:lol%AC_45:rRR?+res?=a=1&b=2#frag", u.String())
+}
+
+func TestParseURN_Strict2141RejectsComponents(t *testing.T) {
+	_, err := Parse("urn:newtonworld228:nss#frag", Strict2141())
+	assert.Error(t, err)
+
+	u, err := Parse("urn:newtonworld228:nss", Strict2141())
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:newtonworld228:nss", u.String())
+}
+
+func TestURN_EqualAndLexicalEquivalent(t *testing.T) {
+	a, err := Parse("urn:NewtonWorld228:lol%ac_45:rRR#frag1")
+	assert.NoError(t, err)
+
+	b, err := Parse("urn:newtonworld228:lol%AC_45:rRR#frag2")
+	assert.NoError(t, err)
+
+	assert.True(t, a.Equal(b))
+	assert.True(t, a.LexicalEquivalent(b))
+
+	c, err := Parse("urn:newtonworld228:other-nss")
+	assert.NoError(t, err)
+
+	assert.False(t, a.Equal(c))
+	assert.False(t, a.LexicalEquivalent(c))
+}
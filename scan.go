@@ -0,0 +1,171 @@
+package urn
+
+import (
+	"bytes"
+	"fmt"
+)
+
+func isAlnum(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+func isNIDByte(b byte) bool {
+	return isAlnum(b) || b == '-'
+}
+
+func isHexDigit(b byte) bool {
+	return (b >= '0' && b <= '9') || (b >= 'a' && b <= 'f') || (b >= 'A' && b <= 'F')
+}
+
+// nssExtraBytes mirrors the non-alphanumeric bytes nssRegexp allows
+// outside of "%HH" escapes.
+const nssExtraBytes = "-+(),.:=@;$_!*'"
+
+func isNSSByte(b byte) bool {
+	return isAlnum(b) || bytes.IndexByte([]byte(nssExtraBytes), b) >= 0
+}
+
+// componentExtraBytes mirrors the non-alphanumeric bytes componentRegexp
+// allows outside of "%HH" escapes, for r/q/f components.
+const componentExtraBytes = "-._~!$&'()*+,;=:@/"
+
+func isComponentByte(b byte) bool {
+	return isAlnum(b) || bytes.IndexByte([]byte(componentExtraBytes), b) >= 0
+}
+
+// scanEscapedClass walks b, checking that every byte either satisfies
+// isAllowed or starts a well-formed "%HH" escape. It returns the offset
+// of the first byte that breaks that rule, or -1 if b is entirely
+// valid.
+func scanEscapedClass(b []byte, isAllowed func(byte) bool) int {
+	for i := 0; i < len(b); i++ {
+		if b[i] == '%' {
+			if i+2 >= len(b) || !isHexDigit(b[i+1]) || !isHexDigit(b[i+2]) {
+				return i
+			}
+
+			i += 2
+
+			continue
+		}
+
+		if !isAllowed(b[i]) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// scanURN walks rawURN once with a hand-written state machine - no
+// bytes.SplitN, no regexp - validating the "urn:" prefix and the NID
+// character class inline, and returns the NID together with the raw
+// tail that still holds the NSS and any RFC 8141 components.
+func scanURN(rawURN []byte) (nid, tail []byte, err error) {
+	if len(rawURN) < len(urnPrefix)+1 {
+		return nil, nil, &ParseError{
+			Kind:   ErrInvalidFormat,
+			Input:  string(rawURN),
+			Offset: 0,
+			Detail: "invalid URN format, should be urn:<nid>:<nss>",
+		}
+	}
+
+	for i := 0; i < len(urnPrefix); i++ {
+		if rawURN[i] != urnPrefix[i] {
+			return nil, nil, &ParseError{
+				Kind:   ErrBadPrefix,
+				Input:  string(rawURN),
+				Offset: i,
+				Detail: fmt.Sprintf("URN must have prefix %s", urnPrefix),
+			}
+		}
+	}
+
+	if rawURN[len(urnPrefix)] != urnDelimiter[0] {
+		return nil, nil, &ParseError{
+			Kind:   ErrBadPrefix,
+			Input:  string(rawURN),
+			Offset: len(urnPrefix),
+			Detail: fmt.Sprintf("URN must have prefix %s", urnPrefix),
+		}
+	}
+
+	rest := rawURN[len(urnPrefix)+1:]
+
+	sep := bytes.IndexByte(rest, urnDelimiter[0])
+	if sep < 0 {
+		return nil, nil, &ParseError{
+			Kind:   ErrInvalidFormat,
+			Input:  string(rawURN),
+			Offset: len(rawURN),
+			Detail: "invalid URN format, should be urn:<nid>:<nss>",
+		}
+	}
+
+	nid = rest[:sep]
+	tail = rest[sep+1:]
+
+	nidStart := len(urnPrefix) + 1
+
+	if len(nid) < minNIDLength || !isAlnum(nid[0]) {
+		return nil, nil, &ParseError{
+			Kind:   ErrBadNIDChar,
+			Input:  string(rawURN),
+			Offset: nidStart,
+			Detail: fmt.Sprintf("NID %s doesn't satisfy pattern: %s", nid, nidRegexp.String()),
+		}
+	}
+
+	for i := 1; i < len(nid); i++ {
+		if !isNIDByte(nid[i]) {
+			return nil, nil, &ParseError{
+				Kind:   ErrBadNIDChar,
+				Input:  string(rawURN),
+				Offset: nidStart + i,
+				Detail: fmt.Sprintf("NID %s doesn't satisfy pattern: %s", nid, nidRegexp.String()),
+			}
+		}
+	}
+
+	if len(nid) > maxNIDLength {
+		return nil, nil, &ParseError{
+			Kind:   ErrBadNIDChar,
+			Input:  string(rawURN),
+			Offset: nidStart,
+			Detail: fmt.Sprintf("NID %s doesn't satisfy pattern: %s", nid, nidRegexp.String()),
+		}
+	}
+
+	// Reject the same reserved/experimental NID prefixes validateNID
+	// does, so Parse/ParseReader/MustParse agree with New instead of
+	// silently accepting them.
+	if len(nid) >= len(experimentalNIDPrefix) && bytes.EqualFold(nid[:len(experimentalNIDPrefix)], experimentalNIDPrefix) {
+		return nil, nil, &ParseError{
+			Kind:   ErrExperimentalNID,
+			Input:  string(rawURN),
+			Offset: nidStart,
+			Detail: fmt.Sprintf("NID %s is experimental", experimentalNIDPrefix),
+		}
+	}
+
+	if len(nid) >= len(xyNIDPrefix) && bytes.EqualFold(nid[:len(xyNIDPrefix)], xyNIDPrefix) {
+		return nil, nil, &ParseError{
+			Kind:   ErrExperimentalNID,
+			Input:  string(rawURN),
+			Offset: nidStart,
+			Detail: fmt.Sprintf("NID %s mustn't start with: %s", nid, xyNIDPrefix),
+		}
+	}
+
+	if len(nid) >= len(reservedNIDPrefix) && bytes.EqualFold(nid[:len(reservedNIDPrefix)], reservedNIDPrefix) {
+		return nil, nil, &ParseError{
+			Kind:   ErrReservedNID,
+			Input:  string(rawURN),
+			Offset: nidStart,
+			Detail: fmt.Sprintf("NID %s is reserved", reservedNIDPrefix),
+		}
+	}
+
+	return nid, tail, nil
+}
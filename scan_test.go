@@ -0,0 +1,54 @@
+package urn
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReader(t *testing.T) {
+	u, err := ParseReader(strings.NewReader("urn:newtonworld228:lol%AC_45:rRR"))
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:newtonworld228:lol%AC_45:rRR", u.String())
+
+	_, err = ParseReader(strings.NewReader("not a urn"))
+	assert.Error(t, err)
+}
+
+func TestURN_WriteTo(t *testing.T) {
+	u, err := New("newtonworld228", "myNSSwith_%23HEXvalue:kek")
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := u.WriteTo(&buf)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(buf.Len()), n)
+	assert.Equal(t, "urn:newtonworld228:myNSSwith_%23HEXvalue:kek", buf.String())
+}
+
+func TestURN_AppendFormat(t *testing.T) {
+	u, err := New("newtonworld228", "myNSSwith_%23HEXvalue:kek")
+	assert.NoError(t, err)
+
+	dst := append([]byte("prefix:"), u.AppendFormat(nil)...)
+	assert.Equal(t, "prefix:urn:newtonworld228:myNSSwith_%23HEXvalue:kek", string(dst))
+}
+
+func TestScanEscapedClass(t *testing.T) {
+	assert.Equal(t, -1, scanEscapedClass([]byte("abc-123"), isNSSByte))
+	assert.Equal(t, 3, scanEscapedClass([]byte("abc?123"), isNSSByte))
+	assert.Equal(t, 3, scanEscapedClass([]byte("abc%2"), isNSSByte))
+}
+
+func BenchmarkParseReader(b *testing.B) {
+	raw := []byte("urn:newtonworld228:myNSSwith_%23HEXvalue:kek")
+
+	for i := 0; i < b.N; i++ {
+		_, err := ParseReader(bytes.NewReader(raw))
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
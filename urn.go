@@ -2,9 +2,11 @@ package urn
 
 import (
 	"bytes"
-	"errors"
+	"encoding/json"
 	"fmt"
+	"io"
 	"regexp"
+	"time"
 )
 
 var (
@@ -39,30 +41,42 @@ const (
 type URN struct {
 	nid []byte
 	nss []byte
+
+	// RFC 8141 components. The has* flags distinguish an absent
+	// component from one that is present but empty (e.g. "urn:nid:nss#"
+	// has an empty, but present, fComponent).
+	rComponent    []byte
+	qComponent    []byte
+	fComponent    []byte
+	hasRComponent bool
+	hasQComponent bool
+	hasFComponent bool
 }
 
 func New(
 	nid string,
 	nss string,
-) (*URN, error) {
+) (u *URN, err error) {
+	start := time.Now()
+	defer func() { observeParse(err, time.Since(start)) }()
+
 	bNID := bytes.TrimSpace([]byte(nid))
 
-	err := validateNID(bNID)
+	err = validateNID(bNID)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"can't create URN, reason: %s",
-			err.Error(),
-		)
+		return nil, err
 	}
 
 	bNSS := bytes.TrimSpace([]byte(nss))
 
 	err = validateNSS(bNSS)
 	if err != nil {
-		return nil, fmt.Errorf(
-			"can't create URN, reason: %s",
-			err.Error(),
-		)
+		return nil, err
+	}
+
+	bNSS, err = applyNamespace(bNID, bNSS)
+	if err != nil {
+		return nil, err
 	}
 
 	return &URN{
@@ -71,16 +85,22 @@ func New(
 	}, nil
 }
 
-func Parse(rawURN string) (*URN, error) {
-	return parseRawURN(
+func Parse(rawURN string, opts ...ParseOption) (u *URN, err error) {
+	start := time.Now()
+	defer func() { observeParse(err, time.Since(start)) }()
+
+	cfg := buildParseConfig(opts)
+
+	u, err = parseRawURN(
 		bytes.TrimSpace([]byte(rawURN)),
+		cfg,
 	)
+
+	return u, err
 }
 
-func MustParse(rawURN string) *URN {
-	u, err := parseRawURN(
-		bytes.TrimSpace([]byte(rawURN)),
-	)
+func MustParse(rawURN string, opts ...ParseOption) *URN {
+	u, err := Parse(rawURN, opts...)
 
 	if err != nil {
 		panic(err)
@@ -89,44 +109,84 @@ func MustParse(rawURN string) *URN {
 	return u
 }
 
-func parseRawURN(rawURN []byte) (*URN, error) {
-	validURNPartsCount := 3
-	tokens := bytes.SplitN(rawURN, urnDelimiter, validURNPartsCount)
+// parseRawURN parses rawURN without bytes.SplitN or regexp: scanURN
+// extracts and validates the NID with a hand-written state machine (no
+// bytes.SplitN, no regexp), and the NSS and r/q/f components are then
+// checked against their character classes the same way.
+func parseRawURN(rawURN []byte, cfg parseConfig) (*URN, error) {
+	nid, tail, err := scanURN(rawURN)
+	if err != nil {
+		return nil, err
+	}
 
-	if len(tokens) != validURNPartsCount {
-		return nil, fmt.Errorf(
-			"invalid URN format, should be urn:<nid>:<nss>",
-		)
+	nss, comps := splitComponents(tail)
+	tailStart := len(rawURN) - len(tail)
+
+	if cfg.strict2141 && (comps.hasRComponent || comps.hasQComponent || comps.hasFComponent) {
+		return nil, &ParseError{
+			Kind:   ErrInvalidFormat,
+			Input:  string(rawURN),
+			Offset: tailStart + len(nss),
+			Detail: "URN uses RFC 8141 components, but strict RFC 2141 parsing was requested",
+		}
 	}
 
-	prefix := tokens[0]
-	if !bytes.Equal(prefix, urnPrefix) {
-		return nil, fmt.Errorf(
-			"URN '%s' must have prefix - %s",
-			rawURN,
-			urnPrefix,
-		)
+	if offset := scanEscapedClass(nss, isNSSByte); offset >= 0 {
+		return nil, &ParseError{
+			Kind:   ErrBadNSSEscape,
+			Input:  string(rawURN),
+			Offset: tailStart + offset,
+			Detail: fmt.Sprintf("NSS %s doesn't satisfy the regexp rule: %s", nss, nssRegexp),
+		}
 	}
 
-	nid := tokens[1]
-	if !nidRegexp.Match(nid) {
-		return nil, fmt.Errorf(
-			"NID %s doesn't satisfy pattern: %s",
-			nid,
-			nidRegexp.String(),
-		)
+	if err := validateComponents(rawURN, tail, comps); err != nil {
+		return nil, err
 	}
 
-	nss := tokens[2]
-	if !nssRegexp.Match(nss) {
+	nss, err = applyNamespace(nid, nss)
+	if err != nil {
+		if parseErr, ok := err.(*ParseError); ok {
+			parseErr.Input = string(rawURN)
+		}
+
+		return nil, err
+	}
+
+	return &URN{
+		nid: nid,
+		nss: nss,
+
+		rComponent:    comps.rComponent,
+		qComponent:    comps.qComponent,
+		fComponent:    comps.fComponent,
+		hasRComponent: comps.hasRComponent,
+		hasQComponent: comps.hasQComponent,
+		hasFComponent: comps.hasFComponent,
+	}, nil
+}
+
+// ParseReader behaves like Parse but reads the raw URN from r, so
+// callers streaming URNs out of a larger document don't need to buffer
+// them into a string first.
+func ParseReader(r io.Reader, opts ...ParseOption) (u *URN, err error) {
+	start := time.Now()
+	defer func() { observeParse(err, time.Since(start)) }()
+
+	raw, err := io.ReadAll(r)
+	if err != nil {
 		return nil, fmt.Errorf(
-			"NSS %s doesn't satisfy the regexp rule: %s",
-			nss,
-			nssRegexp,
+			"can't read URN, reason: %s",
+			err.Error(),
 		)
 	}
 
-	return &URN{nid: nid, nss: nss}, nil
+	u, err = parseRawURN(
+		bytes.TrimSpace(raw),
+		buildParseConfig(opts),
+	)
+
+	return u, err
 }
 
 // String - returns string representation of a URN
@@ -137,68 +197,134 @@ func (urn *URN) String() string {
 }
 
 // MarshalJSON - implements JSON Marshaller interface.
-// Returns a valid JSON string
+// Returns a valid JSON string, or the JSON null literal for a nil or
+// zero-value URN.
 func (urn *URN) MarshalJSON() ([]byte, error) {
+	if urn.IsZero() {
+		return []byte("null"), nil
+	}
+
 	return append(
 		[]byte{34}, append(urn.constructURN(), []byte{34}...)...,
 	), nil
 }
 
+// UnmarshalJSON - implements the JSON Unmarshaller interface. Accepts
+// the JSON null literal, leaving urn as its zero value.
+func (urn *URN) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*urn = URN{}
+		return nil
+	}
+
+	var raw string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf(
+			"can't unmarshal URN, reason: %s",
+			err.Error(),
+		)
+	}
+
+	return urn.UnmarshalText([]byte(raw))
+}
+
 // constructURN - constructs an URN in valid representation
-// e.g. 'urn:<nid>:<nss>'
+// e.g. 'urn:<nid>:<nss>[?+<rComponent>][?=<qComponent>][#<fComponent>]'
 func (urn *URN) constructURN() []byte {
-	return bytes.Join(
-		[][]byte{
-			urnPrefix,
-			urn.nid,
-			urn.nss,
-		},
-		urnDelimiter,
-	)
+	return urn.AppendFormat(nil)
+}
+
+// AppendFormat appends the string representation of urn to dst and
+// returns the extended slice, mirroring time.Time.AppendFormat so
+// callers can serialize into a pooled buffer without an intermediate
+// allocation.
+func (urn *URN) AppendFormat(dst []byte) []byte {
+	dst = append(dst, urnPrefix...)
+	dst = append(dst, urnDelimiter...)
+	dst = append(dst, urn.nid...)
+	dst = append(dst, urnDelimiter...)
+	dst = append(dst, urn.nss...)
+
+	if urn.hasRComponent {
+		dst = append(dst, rComponentDelimiter...)
+		dst = append(dst, urn.rComponent...)
+	}
+
+	if urn.hasQComponent {
+		dst = append(dst, qComponentDelimiter...)
+		dst = append(dst, urn.qComponent...)
+	}
+
+	if urn.hasFComponent {
+		dst = append(dst, fComponentDelimiter...)
+		dst = append(dst, urn.fComponent...)
+	}
+
+	return dst
+}
+
+// WriteTo writes the string representation of urn to w, implementing
+// io.WriterTo. It formats urn into one new buffer with AppendFormat and
+// hands that buffer to a single w.Write call.
+func (urn *URN) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(urn.AppendFormat(nil))
+
+	return int64(n), err
 }
 
 func validateNID(nid []byte) error {
 	if len(nid) < minNIDLength {
-		return errors.New(
-			"length of NID must be more than 2 letters long",
-		)
+		return &ParseError{
+			Kind:   ErrBadNIDChar,
+			Input:  string(nid),
+			Offset: -1,
+			Detail: "length of NID must be more than 2 letters long",
+		}
 	}
 
 	if len(nid) > maxNIDLength {
-		return fmt.Errorf(
-			"NID must be mot greater than %d letters long",
-			maxNIDLength,
-		)
+		return &ParseError{
+			Kind:   ErrBadNIDChar,
+			Input:  string(nid),
+			Offset: -1,
+			Detail: fmt.Sprintf("NID must be mot greater than %d letters long", maxNIDLength),
+		}
 	}
 
 	if bytes.Equal(bytes.ToLower(nid[:2]), experimentalNIDPrefix) {
-		return fmt.Errorf(
-			"NID %s is experimental",
-			experimentalNIDPrefix,
-		)
+		return &ParseError{
+			Kind:   ErrExperimentalNID,
+			Input:  string(nid),
+			Offset: 0,
+			Detail: fmt.Sprintf("NID %s is experimental", experimentalNIDPrefix),
+		}
 	}
 
 	if bytes.Equal(bytes.ToLower(nid[:3]), xyNIDPrefix) {
-		return fmt.Errorf(
-			"NID %s mustn't start with: %s",
-			nid,
-			xyNIDPrefix,
-		)
+		return &ParseError{
+			Kind:   ErrExperimentalNID,
+			Input:  string(nid),
+			Offset: 0,
+			Detail: fmt.Sprintf("NID %s mustn't start with: %s", nid, xyNIDPrefix),
+		}
 	}
 
 	if bytes.Equal(bytes.ToLower(nid[:4]), reservedNIDPrefix) {
-		return fmt.Errorf(
-			"NID %s is reserved",
-			reservedNIDPrefix,
-		)
+		return &ParseError{
+			Kind:   ErrReservedNID,
+			Input:  string(nid),
+			Offset: 0,
+			Detail: fmt.Sprintf("NID %s is reserved", reservedNIDPrefix),
+		}
 	}
 
 	if !nidRegexp.Match(nid) {
-		return fmt.Errorf(
-			"NID %s doesn't satisfy pattern: %s",
-			nid,
-			nidRegexp.String(),
-		)
+		return &ParseError{
+			Kind:   ErrBadNIDChar,
+			Input:  string(nid),
+			Offset: -1,
+			Detail: fmt.Sprintf("NID %s doesn't satisfy pattern: %s", nid, nidRegexp.String()),
+		}
 	}
 
 	return nil
@@ -206,16 +332,21 @@ func validateNID(nid []byte) error {
 
 func validateNSS(nss []byte) error {
 	if len(nss) < minNSSLength {
-		return fmt.Errorf(
-			"NSS must be at least one character long",
-		)
+		return &ParseError{
+			Kind:   ErrBadNSSEscape,
+			Input:  string(nss),
+			Offset: -1,
+			Detail: "NSS must be at least one character long",
+		}
 	}
 
 	if !nssRegexp.Match(nss) {
-		return fmt.Errorf(
-			"NSS doesn't satisfy the regexp rule: %s",
-			nssRegexp,
-		)
+		return &ParseError{
+			Kind:   ErrBadNSSEscape,
+			Input:  string(nss),
+			Offset: -1,
+			Detail: fmt.Sprintf("NSS doesn't satisfy the regexp rule: %s", nssRegexp),
+		}
 	}
 
 	return nil
@@ -21,8 +21,8 @@ func TestParseURN(t *testing.T) {
 			name: "valid URN",
 			URN:  "urn:newtonworld228:lol%AC_45:rRR",
 			expectedURN: &URN{
-				[]byte("newtonworld228"),
-				[]byte("lol%AC_45:rRR"),
+				nid: []byte("newtonworld228"),
+				nss: []byte("lol%AC_45:rRR"),
 			},
 			expectedError: nil,
 		},
@@ -30,26 +30,26 @@ func TestParseURN(t *testing.T) {
 			name:          "empty URN",
 			URN:           "",
 			expectedURN:   nil,
-			expectedError: errors.New("invalid URN format, should be urn:<nid>:<nss>"),
+			expectedError: errors.New("urn: invalid format: invalid URN format, should be urn:<nid>:<nss> (at byte offset 0 in \"\")"),
 		},
 		{
 			name:          "wrong prefix in URN",
 			URN:           "irn:nid456:nss_$:kek",
 			expectedURN:   nil,
-			expectedError: errors.New("URN 'irn:nid456:nss_$:kek' must have prefix - urn"),
+			expectedError: errors.New("urn: bad prefix: URN must have prefix urn (at byte offset 0 in \"irn:nid456:nss_$:kek\")"),
 		},
 		{
 			name:          "wrong NID",
 			URN:           "urn:$sdf:nss_$:kek",
 			expectedURN:   nil,
-			expectedError: errors.New("NID $sdf doesn't satisfy pattern: ^[a-zA-Z0-9]{1}[a-zA-Z0-9\\-]{1,31}$"),
+			expectedError: errors.New("urn: bad NID character: NID $sdf doesn't satisfy pattern: ^[a-zA-Z0-9]{1}[a-zA-Z0-9\\-]{1,31}$ (at byte offset 4 in \"urn:$sdf:nss_$:kek\")"),
 		},
 		{
 			name:        "wrong NSS",
 			URN:         "urn:newtonworld228:?kek:lol",
 			expectedURN: nil,
 			expectedError: errors.New(
-				"NSS ?kek:lol doesn't satisfy the regexp rule: ^(?:%[0-9A-Fa-f]{2}|[a-zA-Z0-9\\-+(),.:=@;$_!*'])+$"),
+				"urn: bad NSS escape: NSS ?kek:lol doesn't satisfy the regexp rule: ^(?:%[0-9A-Fa-f]{2}|[a-zA-Z0-9\\-+(),.:=@;$_!*'])+$ (at byte offset 19 in \"urn:newtonworld228:?kek:lol\")"),
 		},
 	}
 
@@ -59,7 +59,12 @@ func TestParseURN(t *testing.T) {
 			func(t *testing.T) {
 				u, err := Parse(testCase.URN)
 				assert.Equal(t, testCase.expectedURN, u)
-				assert.Equal(t, testCase.expectedError, err)
+
+				if testCase.expectedError == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, testCase.expectedError.Error())
+				}
 			},
 		)
 	}
@@ -69,7 +74,7 @@ func BenchmarkParseURN(b *testing.B) {
 	rawURN := []byte("urn:newtonworld228:myNSSwith_%23HEXvalue:kek")
 
 	for i := 0; i < b.N; i++ {
-		_, err := parseRawURN(rawURN)
+		_, err := parseRawURN(rawURN, parseConfig{})
 		if err != nil {
 			fmt.Printf(
 				"error: %s\n", err,
@@ -113,7 +118,7 @@ func BenchmarkJSONMarshalTest(b *testing.B) {
 }
 
 func TestMarshalJSON(t *testing.T) {
-	someURN := &URN{[]byte("newtonworld228"), []byte("myNSSwith_%23HEXvalue:kek")}
+	someURN := &URN{nid: []byte("newtonworld228"), nss: []byte("myNSSwith_%23HEXvalue:kek")}
 	someStruct := struct {
 		Urn *URN `json:"myurn"`
 	}{Urn: someURN}
@@ -164,7 +169,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "user:test_-user",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: length of NID must be more than 2 letters long"),
+			expectedError:     errors.New("urn: bad NID character: length of NID must be more than 2 letters long"),
 		},
 		{
 			name:              "too long nid and valid nss",
@@ -172,7 +177,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "user:test_-user",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: NID must be mot greater than 32 letters long"),
+			expectedError:     errors.New("urn: bad NID character: NID must be mot greater than 32 letters long"),
 		},
 		{
 			name:              "reserved nid and valid nss",
@@ -180,7 +185,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "user:test_-user",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: NID urn- is reserved"),
+			expectedError:     errors.New("urn: reserved NID: NID urn- is reserved (at byte offset 0 in \"urn-nid\")"),
 		},
 		{
 			name:              "experimental nid and valid nss",
@@ -188,7 +193,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "user:test_-user",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: NID x- is experimental"),
+			expectedError:     errors.New("urn: experimental NID: NID x- is experimental (at byte offset 0 in \"x-nid\")"),
 		},
 		{
 			name:              "experimental xy- nid and valid nss",
@@ -196,7 +201,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "user:test_-user",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: NID XY-nid mustn't start with: xy-"),
+			expectedError:     errors.New("urn: experimental NID: NID XY-nid mustn't start with: xy- (at byte offset 0 in \"XY-nid\")"),
 		},
 		{
 			name:              "wrong characters in nid and valid nss",
@@ -204,7 +209,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "user:test_-user",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: NID _$nid doesn't satisfy pattern: ^[a-zA-Z0-9]{1}[a-zA-Z0-9\\-]{1,31}$"),
+			expectedError:     errors.New("urn: bad NID character: NID _$nid doesn't satisfy pattern: ^[a-zA-Z0-9]{1}[a-zA-Z0-9\\-]{1,31}$"),
 		},
 		{
 			name:              "valid nid and empty nss",
@@ -212,7 +217,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: NSS must be at least one character long"),
+			expectedError:     errors.New("urn: bad NSS escape: NSS must be at least one character long"),
 		},
 		{
 			name:              "valid nid and invalid nss",
@@ -220,7 +225,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "%%lol?kek",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: NSS doesn't satisfy the regexp rule: ^(?:%[0-9A-Fa-f]{2}|[a-zA-Z0-9\\-+(),.:=@;$_!*'])+$"),
+			expectedError:     errors.New("urn: bad NSS escape: NSS doesn't satisfy the regexp rule: ^(?:%[0-9A-Fa-f]{2}|[a-zA-Z0-9\\-+(),.:=@;$_!*'])+$"),
 		},
 		{
 			name:              "empty nid and nss",
@@ -228,7 +233,7 @@ func TestNewURN(t *testing.T) {
 			nss:               "",
 			expectedURN:       nil,
 			expectedStringURN: "",
-			expectedError:     errors.New("can't create URN, reason: length of NID must be more than 2 letters long"),
+			expectedError:     errors.New("urn: bad NID character: length of NID must be more than 2 letters long"),
 		},
 	}
 
@@ -246,7 +251,12 @@ func TestNewURN(t *testing.T) {
 				if u != nil {
 					assert.Equal(t, testCase.expectedStringURN, u.String())
 				}
-				assert.Equal(t, testCase.expectedError, err)
+
+				if testCase.expectedError == nil {
+					assert.NoError(t, err)
+				} else {
+					assert.EqualError(t, err, testCase.expectedError.Error())
+				}
 			},
 		)
 	}
@@ -0,0 +1,89 @@
+// Package urnmetrics wires the urn package's parse observer into
+// Prometheus metrics. It lives in its own package so that importing urn
+// itself never pulls in the Prometheus client library.
+package urnmetrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"boomfunc/urn"
+)
+
+var (
+	parseTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "urn_parse_total",
+			Help: "Total number of URN parse/construct attempts, by result and failure reason.",
+		},
+		[]string{"result", "reason"},
+	)
+
+	parseDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "urn_parse_duration_seconds",
+			Help:    "Latency of urn.New, urn.Parse and urn.ParseReader calls.",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+)
+
+// RegisterMetrics registers this package's collectors with reg and
+// points urn.SetParseObserver at them, so every New, Parse, MustParse and
+// ParseReader call is reflected in urn_parse_total and
+// urn_parse_duration_seconds from then on. Call it once during startup.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	if err := reg.Register(parseTotal); err != nil {
+		return err
+	}
+
+	if err := reg.Register(parseDuration); err != nil {
+		return err
+	}
+
+	urn.SetParseObserver(observe)
+
+	return nil
+}
+
+func observe(err error, duration time.Duration) {
+	parseDuration.Observe(duration.Seconds())
+
+	if err == nil {
+		parseTotal.WithLabelValues("ok", "").Inc()
+		return
+	}
+
+	parseTotal.WithLabelValues("error", reasonFor(err)).Inc()
+}
+
+// reasonFor maps err to a low-cardinality label value, falling back to
+// "other" for errors that aren't a *urn.ParseError (e.g. a failure to
+// read from ParseReader's io.Reader).
+func reasonFor(err error) string {
+	var parseErr *urn.ParseError
+	if !errors.As(err, &parseErr) {
+		return "other"
+	}
+
+	switch {
+	case errors.Is(parseErr.Kind, urn.ErrInvalidFormat):
+		return "invalid_format"
+	case errors.Is(parseErr.Kind, urn.ErrBadPrefix):
+		return "bad_prefix"
+	case errors.Is(parseErr.Kind, urn.ErrReservedNID):
+		return "reserved_nid"
+	case errors.Is(parseErr.Kind, urn.ErrExperimentalNID):
+		return "experimental_nid"
+	case errors.Is(parseErr.Kind, urn.ErrBadNIDChar):
+		return "bad_nid_char"
+	case errors.Is(parseErr.Kind, urn.ErrBadNSSEscape):
+		return "bad_nss_escape"
+	case errors.Is(parseErr.Kind, urn.ErrNamespaceInvalid):
+		return "namespace_invalid"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,134 @@
+package urnmetrics
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+
+	"boomfunc/urn"
+)
+
+func TestReasonFor(t *testing.T) {
+	testCases := []struct {
+		name string
+		err  error
+
+		expectedReason string
+	}{
+		{
+			name:           "invalid format",
+			err:            &urn.ParseError{Kind: urn.ErrInvalidFormat},
+			expectedReason: "invalid_format",
+		},
+		{
+			name:           "bad prefix",
+			err:            &urn.ParseError{Kind: urn.ErrBadPrefix},
+			expectedReason: "bad_prefix",
+		},
+		{
+			name:           "reserved NID",
+			err:            &urn.ParseError{Kind: urn.ErrReservedNID},
+			expectedReason: "reserved_nid",
+		},
+		{
+			name:           "experimental NID",
+			err:            &urn.ParseError{Kind: urn.ErrExperimentalNID},
+			expectedReason: "experimental_nid",
+		},
+		{
+			name:           "bad NID character",
+			err:            &urn.ParseError{Kind: urn.ErrBadNIDChar},
+			expectedReason: "bad_nid_char",
+		},
+		{
+			name:           "bad NSS escape",
+			err:            &urn.ParseError{Kind: urn.ErrBadNSSEscape},
+			expectedReason: "bad_nss_escape",
+		},
+		{
+			name:           "namespace validation failure",
+			err:            &urn.ParseError{Kind: urn.ErrNamespaceInvalid},
+			expectedReason: "namespace_invalid",
+		},
+		{
+			name:           "unwrapped error",
+			err:            errors.New("some other error"),
+			expectedReason: "other",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(
+			testCase.name,
+			func(t *testing.T) {
+				if got := reasonFor(testCase.err); got != testCase.expectedReason {
+					t.Errorf("reasonFor(%v) = %q, want %q", testCase.err, got, testCase.expectedReason)
+				}
+			},
+		)
+	}
+}
+
+func TestRegisterMetrics(t *testing.T) {
+	defer urn.SetParseObserver(nil)
+
+	reg := prometheus.NewRegistry()
+	if err := RegisterMetrics(reg); err != nil {
+		t.Fatalf("RegisterMetrics() = %v, want nil", err)
+	}
+
+	if _, err := urn.Parse("urn:newtonworld228:nss"); err != nil {
+		t.Fatalf("Parse() = %v, want nil", err)
+	}
+
+	if _, err := urn.Parse("not-a-urn"); err == nil {
+		t.Fatal("Parse() = nil, want error")
+	}
+
+	if _, err := urn.New("urn-nid", "nss"); err == nil {
+		t.Fatal("New() = nil, want error")
+	}
+
+	if got := testutil.ToFloat64(parseTotal.WithLabelValues("ok", "")); got != 1 {
+		t.Errorf("urn_parse_total{result=ok} = %v, want 1", got)
+	}
+
+	if got := testutil.ToFloat64(parseTotal.WithLabelValues("error", "bad_prefix")); got != 1 {
+		t.Errorf(`urn_parse_total{result=error,reason=bad_prefix} = %v, want 1`, got)
+	}
+
+	if got := testutil.ToFloat64(parseTotal.WithLabelValues("error", "reserved_nid")); got != 1 {
+		t.Errorf(`urn_parse_total{result=error,reason=reserved_nid} = %v, want 1`, got)
+	}
+
+	var metric dto.Metric
+	if err := parseDuration.Write(&metric); err != nil {
+		t.Fatalf("parseDuration.Write() = %v, want nil", err)
+	}
+
+	if got := metric.GetHistogram().GetSampleCount(); got != 3 {
+		t.Errorf("urn_parse_duration_seconds sample count = %d, want 3", got)
+	}
+
+	gathered, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() = %v, want nil", err)
+	}
+
+	var sawTotal, sawDuration bool
+	for _, mf := range gathered {
+		switch mf.GetName() {
+		case "urn_parse_total":
+			sawTotal = true
+		case "urn_parse_duration_seconds":
+			sawDuration = true
+		}
+	}
+
+	if !sawTotal || !sawDuration {
+		t.Errorf("Gather() = %v, want both urn_parse_total and urn_parse_duration_seconds registered", gathered)
+	}
+}